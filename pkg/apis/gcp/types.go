@@ -0,0 +1,145 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+// InfrastructureConfig infrastructure configuration resource.
+type InfrastructureConfig struct {
+	// Networks is the network configuration (VPC, subnets, etc.)
+	Networks NetworkConfig
+}
+
+// NetworkConfig holds information about the Kubernetes and infrastructure networks.
+type NetworkConfig struct {
+	// VPC indicates whether to use an existing VPC or create a new one.
+	VPC *VPC
+	// CloudNAT contains configuration about the Cloud NAT.
+	CloudNAT *CloudNAT
+	// Internal is a private subnet (used for internal load balancers).
+	Internal *string
+	// Worker is the worker subnet range to create (used for the VMs).
+	// Deprecated - use `Workers` instead.
+	Worker string
+	// Workers is the worker subnet range to create (used for the VMs).
+	Workers string
+	// FlowLogs contains the flow log configuration for the subnet.
+	FlowLogs *FlowLogs
+	// IPFamilies specifies the IP protocol versions to use for the shoot VPC. Defaults to
+	// IPv4-only if empty. Adding IPFamilyIPv6 enables dual-stack subnets.
+	IPFamilies []IPFamily
+	// NodesIPv6AccessType is the IPv6 access type of the nodes subnet, required when IPFamilies
+	// contains IPFamilyIPv6.
+	NodesIPv6AccessType *string
+	// InternalIPv6AccessType is the IPv6 access type of the internal subnet, required when
+	// IPFamilies contains IPFamilyIPv6 and Internal is set.
+	InternalIPv6AccessType *string
+	// APIServerAccess configures an internal-only load balancer for the API server, reachable
+	// from within the shoot VPC and from a bastion/jump host subnet.
+	APIServerAccess *APIServerAccessConfig
+	// PrivateServiceAccess configures a VPC peering connection for privately accessing Google
+	// managed services (e.g. Cloud SQL) from within the shoot VPC.
+	PrivateServiceAccess *PrivateServiceAccessConfig
+}
+
+// APIServerAccessConfig configures an internal-only API server load balancer.
+type APIServerAccessConfig struct {
+	// BastionSubnet is the CIDR of a dedicated subnet for a bastion/jump host used to reach the
+	// internal load balancer. If unset, no bastion subnet is created.
+	BastionSubnet *string
+}
+
+// PrivateServiceAccessConfig configures private access to Google managed services: a VPC peering
+// connection to servicenetworking.googleapis.com, and optionally a PSC service connection policy
+// per PSC-enabled producer.
+type PrivateServiceAccessConfig struct {
+	// Name is the name of the global address range reserved for the peering connection.
+	Name string
+	// CIDR is the CIDR of the global address range reserved for the peering connection.
+	CIDR string
+	// Producers are the PSC-enabled managed service classes to create a
+	// google_network_connectivity_service_connection_policy for (e.g. Cloud SQL, Memorystore,
+	// AlloyDB), in addition to the VPC peering connection to servicenetworking.googleapis.com
+	// that is always created.
+	Producers []string
+	// ExportCustomRoutes indicates whether custom routes should be exported to the peered
+	// network. Defaults to false.
+	ExportCustomRoutes *bool
+	// ImportCustomRoutes indicates whether custom routes should be imported from the peered
+	// network. Defaults to false.
+	ImportCustomRoutes *bool
+}
+
+// VPC contains information about the VPC and some related resources.
+type VPC struct {
+	// Name is the VPC name.
+	Name string
+	// CloudRouter indicates whether a new CloudRouter should be created or the existing one
+	// with the given name should be used.
+	CloudRouter *CloudRouter
+	// HostProject is the project ID of the Shared VPC (XPN) host project the VPC lives in. If
+	// set, the nodes and internal subnets are created in this host project, while the other
+	// resources (Cloud Router, Cloud NAT, firewall rule, service account) are created in the
+	// shoot's own (service) project.
+	HostProject *string
+}
+
+// CloudRouter contains information about the CloudRouter configuration.
+type CloudRouter struct {
+	// Name is the name of an existing CloudRouter at the specified VPC.
+	Name string
+}
+
+// CloudNAT contains configuration about the Cloud NAT.
+type CloudNAT struct {
+	// MinPortsPerVM is the minimum number of ports allocated to a VM from this NAT config.
+	MinPortsPerVM *int32
+	// MaxPortsPerVM is the maximum number of ports allocated to a VM from this NAT config, used
+	// together with EnableDynamicPortAllocation.
+	MaxPortsPerVM *int32
+	// NatIPNames are the names of reserved, static external IP addresses to use for this NAT
+	// config, instead of having Google allocate them automatically.
+	NatIPNames []string
+	// EnableEndpointIndependentMapping enables endpoint-independent mapping for this NAT config.
+	EnableEndpointIndependentMapping *bool
+	// EnableDynamicPortAllocation enables dynamic port allocation for this NAT config.
+	EnableDynamicPortAllocation *bool
+	// UdpIdleTimeoutSec is the timeout for UDP connections.
+	UdpIdleTimeoutSec *int32
+	// TcpEstablishedIdleTimeoutSec is the timeout for established TCP connections.
+	TcpEstablishedIdleTimeoutSec *int32
+	// TcpTransitoryIdleTimeoutSec is the timeout for transitory TCP connections.
+	TcpTransitoryIdleTimeoutSec *int32
+	// IcmpIdleTimeoutSec is the timeout for ICMP connections.
+	IcmpIdleTimeoutSec *int32
+}
+
+// IPFamily is an IP protocol version to be used for the shoot VPC.
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 is the IPv4 family.
+	IPFamilyIPv4 IPFamily = "IPv4"
+	// IPFamilyIPv6 is the IPv6 family.
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
+// FlowLogs contains the configuration options for the VPC flow logs.
+type FlowLogs struct {
+	// AggregationInterval for collecting flow logs.
+	AggregationInterval *string
+	// FlowSampling sets the sampling rate of VPC flow logs within the subnetwork.
+	FlowSampling *float32
+	// Metadata configures whether metadata fields should be added to the reported VPC flow logs.
+	Metadata *string
+}
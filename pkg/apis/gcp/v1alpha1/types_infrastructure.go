@@ -0,0 +1,100 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InfrastructureStatus contains information about created infrastructure resources.
+type InfrastructureStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Networks is the status of the networks of the infrastructure.
+	Networks NetworkStatus `json:"networks"`
+	// ServiceAccountEmail is the email address of the service account.
+	ServiceAccountEmail string `json:"serviceAccountEmail"`
+}
+
+// NetworkStatus is the current status of the infrastructure networks.
+type NetworkStatus struct {
+	// VPC states the name of the infrastructure VPC.
+	VPC VPC `json:"vpc"`
+	// Subnets are the subnets that have been created.
+	Subnets []Subnet `json:"subnets"`
+	// APIServerAccess is the status of the internal API server load balancer, if configured.
+	APIServerAccess *APIServerAccessStatus `json:"apiServerAccess,omitempty"`
+	// PrivateServiceAccess is the status of the Private Service Access VPC peering connection,
+	// if configured.
+	PrivateServiceAccess *PrivateServiceAccessStatus `json:"privateServiceAccess,omitempty"`
+	// NatIPs are the static external IP addresses reserved for Cloud NAT, if NatIPNames is
+	// configured.
+	NatIPs []string `json:"natIPs,omitempty"`
+}
+
+// PrivateServiceAccessStatus is the status of a Private Service Access VPC peering connection.
+type PrivateServiceAccessStatus struct {
+	// ConnectionName is the name of the google_service_networking_connection.
+	ConnectionName string `json:"connectionName"`
+	// Ranges are the reserved global internal IP ranges allocated for the peering connection.
+	Ranges []string `json:"ranges,omitempty"`
+}
+
+// APIServerAccessStatus is the status of an internal-only API server load balancer.
+type APIServerAccessStatus struct {
+	// InternalLBAddress is the IP address of the internal TCP/UDP load balancer fronting the API
+	// server.
+	InternalLBAddress string `json:"internalLBAddress"`
+	// BastionSubnet is the name of the bastion/jump host subnet, if one was created.
+	BastionSubnet string `json:"bastionSubnet,omitempty"`
+	// BastionInstanceName is the name of the bastion/jump host instance, if one was created.
+	BastionInstanceName string `json:"bastionInstanceName,omitempty"`
+}
+
+// VPC contains information about the VPC and some related resources.
+type VPC struct {
+	// Name is the VPC name.
+	Name string `json:"name"`
+	// CloudRouter is the name of the cloud router.
+	CloudRouter *CloudRouter `json:"cloudRouter,omitempty"`
+	// HostProject is the project ID of the Shared VPC (XPN) host project the VPC lives in, if any.
+	HostProject *string `json:"hostProject,omitempty"`
+}
+
+// CloudRouter contains information about the CloudRouter configuration.
+type CloudRouter struct {
+	// Name is the name of the cloud router.
+	Name string `json:"name"`
+}
+
+// Purpose is a purpose of a subnet.
+type Purpose string
+
+const (
+	// PurposeNodes is a Purpose for the subnet used by nodes.
+	PurposeNodes Purpose = "nodes"
+	// PurposeInternal is a Purpose for the subnet used for internal load balancers.
+	PurposeInternal Purpose = "internal"
+)
+
+// Subnet is a subnet that was created.
+type Subnet struct {
+	// Name is the name of the subnet.
+	Name string `json:"name"`
+	// Purpose is the purpose of the subnet.
+	Purpose Purpose `json:"purpose"`
+	// IPv6CIDR is the allocated IPv6 CIDR range of the subnet, if dual-stack is enabled.
+	IPv6CIDR *string `json:"ipv6CIDR,omitempty"`
+}
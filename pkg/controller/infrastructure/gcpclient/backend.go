@@ -0,0 +1,188 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	infrainternal "github.com/gardener/gardener-extension-provider-gcp/pkg/internal/infrastructure"
+	"github.com/gardener/gardener-extensions/pkg/terraformer"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// Backend is the InfrastructureBackend implementation that reconciles the infrastructure
+// resources natively through the Google Cloud SDK, without a Terraformer pod.
+type Backend struct {
+	client    *Client
+	infra     *extensionsv1alpha1.Infrastructure
+	config    *api.InfrastructureConfig
+	namespace string
+
+	// imported is the Terraformer state of a shoot that is switching from the Terraformer to
+	// the native backend. When set, the resource names below are reused instead of generated,
+	// so existing resources are adopted rather than recreated.
+	imported *infrainternal.TerraformState
+}
+
+// NewBackend creates a new native Backend for the given Infrastructure and InfrastructureConfig.
+func NewBackend(client *Client, infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig) *Backend {
+	return &Backend{
+		client:    client,
+		infra:     infra,
+		config:    config,
+		namespace: infra.Namespace,
+	}
+}
+
+// NewBackendFromTerraformState creates a native Backend that adopts the resources recorded in
+// the given Terraformer state, for shoots switching away from the Terraformer backend.
+func NewBackendFromTerraformState(client *Client, infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig, imported *infrainternal.TerraformState) *Backend {
+	backend := NewBackend(client, infra, config)
+	backend.imported = imported
+	return backend
+}
+
+// unsupportedFlowFeatures lists the InfrastructureConfig features that are not implemented by
+// this native backend yet, so that a shoot relying on one of them is never silently switched to
+// a materially different (and likely incomplete) reconciliation.
+func unsupportedFlowFeatures(config *api.InfrastructureConfig) []string {
+	var unsupported []string
+
+	if config.Networks.APIServerAccess != nil {
+		unsupported = append(unsupported, "networks.apiServerAccess")
+	}
+	if config.Networks.PrivateServiceAccess != nil {
+		unsupported = append(unsupported, "networks.privateServiceAccess")
+	}
+	if len(config.Networks.IPFamilies) > 0 {
+		unsupported = append(unsupported, "networks.ipFamilies")
+	}
+	if config.Networks.VPC != nil && config.Networks.VPC.HostProject != nil {
+		unsupported = append(unsupported, "networks.vpc.hostProject")
+	}
+	if config.Networks.CloudNAT != nil && len(config.Networks.CloudNAT.NatIPNames) > 0 {
+		unsupported = append(unsupported, "networks.cloudNAT.natIPNames")
+	}
+
+	return unsupported
+}
+
+// ValidateFlowBackendSupport returns an error if the given InfrastructureConfig uses a feature
+// this native backend does not implement. Callers must reject the UseFlowAnnotation rather than
+// reconcile a materially different infrastructure.
+func ValidateFlowBackendSupport(config *api.InfrastructureConfig) error {
+	if unsupported := unsupportedFlowFeatures(config); len(unsupported) > 0 {
+		return fmt.Errorf("the native (flow) backend does not yet support: %s", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// Reconcile creates or updates the VPC, subnets, Cloud Router, Cloud NAT, firewall rules and
+// service account for the shoot, and returns the resulting InfrastructureStatus.
+func (b *Backend) Reconcile(ctx context.Context) (*apiv1alpha1.InfrastructureStatus, error) {
+	if err := ValidateFlowBackendSupport(b.config); err != nil {
+		return nil, err
+	}
+
+	vpc, err := b.reconcileVPC(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconcile VPC: %w", err)
+	}
+
+	subnets, err := b.reconcileSubnets(ctx, vpc)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconcile subnets: %w", err)
+	}
+
+	router, err := b.reconcileCloudRouter(ctx, vpc)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconcile Cloud Router: %w", err)
+	}
+
+	nat, err := b.reconcileCloudNAT(ctx, router)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconcile Cloud NAT: %w", err)
+	}
+
+	if err := b.reconcileFirewallRules(ctx, vpc); err != nil {
+		return nil, fmt.Errorf("could not reconcile firewall rules: %w", err)
+	}
+
+	serviceAccountEmail, err := b.reconcileServiceAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconcile service account: %w", err)
+	}
+
+	status := &apiv1alpha1.InfrastructureStatus{
+		TypeMeta: infrainternal.StatusTypeMeta,
+		Networks: apiv1alpha1.NetworkStatus{
+			VPC: apiv1alpha1.VPC{
+				Name: vpc,
+				CloudRouter: &apiv1alpha1.CloudRouter{
+					Name: router,
+				},
+			},
+			Subnets: subnets,
+		},
+		ServiceAccountEmail: serviceAccountEmail,
+	}
+
+	_ = nat // the Cloud NAT name itself is not surfaced on the status today, only its effects
+
+	return status, nil
+}
+
+// Delete removes the resources this backend created for the shoot. The VPC, Cloud Router and
+// Cloud NAT are located by the deterministic name derived from the shoot namespace (or, for
+// adopted shoots, from the imported Terraform state) and are skipped when the user brought their
+// own. Subnetworks additionally carry the OwnerLabelKey label (client.go), and are looked up by
+// that label rather than by name, since Compute Engine firewall rules and VPCs/routers in this
+// API version do not support labels at all.
+func (b *Backend) Delete(ctx context.Context) error {
+	if err := b.deleteCloudNAT(ctx); err != nil {
+		return fmt.Errorf("could not delete Cloud NAT: %w", err)
+	}
+
+	if err := b.deleteCloudRouter(ctx); err != nil {
+		return fmt.Errorf("could not delete Cloud Router: %w", err)
+	}
+
+	if err := b.deleteFirewallRules(ctx); err != nil {
+		return fmt.Errorf("could not delete firewall rules: %w", err)
+	}
+
+	if err := b.deleteSubnets(ctx); err != nil {
+		return fmt.Errorf("could not delete subnets: %w", err)
+	}
+
+	if err := b.deleteVPC(ctx); err != nil {
+		return fmt.Errorf("could not delete VPC: %w", err)
+	}
+
+	return b.deleteServiceAccount(ctx)
+}
+
+// ImportTerraformState reads the existing Terraformer state for an infrastructure so that
+// switching a shoot from the Terraformer backend to the native backend does not recreate any
+// resources. The returned TerraformState can be used to seed the native backend's view of
+// already-existing resources.
+func ImportTerraformState(tf terraformer.Terraformer, config *api.InfrastructureConfig) (*infrainternal.TerraformState, error) {
+	return infrainternal.ExtractTerraformState(tf, config)
+}
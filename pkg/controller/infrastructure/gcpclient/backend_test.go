@@ -0,0 +1,63 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"testing"
+
+	api "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+func TestBackendWorkersCIDR(t *testing.T) {
+	tests := []struct {
+		name   string
+		config api.NetworkConfig
+		want   string
+	}{
+		{
+			name:   "Workers set",
+			config: api.NetworkConfig{Workers: "10.250.0.0/16"},
+			want:   "10.250.0.0/16",
+		},
+		{
+			name:   "only deprecated Worker set",
+			config: api.NetworkConfig{Worker: "10.250.0.0/16"},
+			want:   "10.250.0.0/16",
+		},
+		{
+			name:   "both set prefers Workers",
+			config: api.NetworkConfig{Workers: "10.250.0.0/16", Worker: "10.251.0.0/16"},
+			want:   "10.250.0.0/16",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := NewBackend(nil, &extensionsv1alpha1.Infrastructure{}, &api.InfrastructureConfig{Networks: test.config})
+			if got := backend.workersCIDR(); got != test.want {
+				t.Errorf("workersCIDR() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateFlowBackendSupport(t *testing.T) {
+	config := &api.InfrastructureConfig{Networks: api.NetworkConfig{Workers: "10.250.0.0/16"}}
+
+	if err := ValidateFlowBackendSupport(config); err != nil {
+		t.Errorf("expected a plain config with no advanced features to be supported, got %v", err)
+	}
+}
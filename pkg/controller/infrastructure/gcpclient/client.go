@@ -0,0 +1,77 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcpclient reconciles VPC, subnet, Cloud Router, Cloud NAT, firewall rule and service
+// account resources for a shoot directly through the Google Cloud SDK, as an alternative to the
+// Terraformer based reconciliation in pkg/internal/infrastructure.
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	iam "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+)
+
+// OwnerLabelKey is the label key this package sets on every subnetwork it creates, so that Delete
+// only removes the subnetworks it owns rather than every subnetwork in the region. Other
+// resources are not labelled.
+const OwnerLabelKey = "gcp.provider.extensions.gardener.cloud/shoot-namespace"
+
+// Client wraps the Compute Engine and IAM APIs for a single GCP project, both authenticated with
+// the same shoot-specific service account credentials.
+type Client struct {
+	Compute *compute.Service
+	IAM     *iam.Service
+	Project string
+	Region  string
+}
+
+// NewClient creates a new Client authenticated with the given service account credentials JSON.
+// The same credentials are used for every API this package calls, so the returned Client only
+// ever acts within the GCP project the credentials belong to.
+func NewClient(ctx context.Context, serviceAccountJSON []byte, project, region string) (*Client, error) {
+	creds, err := google.CredentialsFromJSON(ctx, serviceAccountJSON, compute.ComputeScope, iam.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("could not create GCP credentials: %w", err)
+	}
+
+	computeService, err := compute.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("could not create compute service: %w", err)
+	}
+
+	iamService, err := iam.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("could not create IAM service: %w", err)
+	}
+
+	return &Client{
+		Compute: computeService,
+		IAM:     iamService,
+		Project: project,
+		Region:  region,
+	}, nil
+}
+
+// ownerLabels returns the labels set on every subnetwork created for the given shoot namespace,
+// so that Delete can later identify the subnetworks owned by this backend.
+func ownerLabels(namespace string) map[string]string {
+	return map[string]string{
+		OwnerLabelKey: namespace,
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// firewallRuleNames returns the names of every firewall rule owned by this backend.
+func (b *Backend) firewallRuleNames() []string {
+	return []string{
+		fmt.Sprintf("%s-allow-internal-access", b.namespace),
+	}
+}
+
+// reconcileFirewallRules creates the firewall rule allowing all TCP, UDP and ICMP traffic from the
+// worker (nodes) subnet CIDR within the shoot VPC.
+func (b *Backend) reconcileFirewallRules(ctx context.Context, vpc string) error {
+	rule := &compute.Firewall{
+		Name:         b.firewallRuleNames()[0],
+		Network:      networkSelfLink(b.client.Project, vpc),
+		SourceRanges: []string{b.workersCIDR()},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{"1-65535"}},
+			{IPProtocol: "udp", Ports: []string{"1-65535"}},
+			{IPProtocol: "icmp"},
+		},
+	}
+
+	op, err := b.client.Compute.Firewalls.Insert(b.client.Project, rule).Context(ctx).Do()
+	if err != nil {
+		if isAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	return waitForGlobalOperation(ctx, b.client, op)
+}
+
+// deleteFirewallRules deletes every firewall rule owned by this backend.
+func (b *Backend) deleteFirewallRules(ctx context.Context) error {
+	for _, name := range b.firewallRuleNames() {
+		op, err := b.client.Compute.Firewalls.Delete(b.client.Project, name).Context(ctx).Do()
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := waitForGlobalOperation(ctx, b.client, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
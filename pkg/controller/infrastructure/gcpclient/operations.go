@@ -0,0 +1,74 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// pollInterval is how often a pending Compute Engine operation is polled for completion.
+const pollInterval = 2 * time.Second
+
+// waitForGlobalOperation blocks until the given global Compute Engine operation (e.g. a network
+// create/delete) has finished, and returns an error if it failed.
+func waitForGlobalOperation(ctx context.Context, client *Client, op *compute.Operation) error {
+	return waitForOperation(ctx, op, func() (*compute.Operation, error) {
+		return client.Compute.GlobalOperations.Get(client.Project, op.Name).Context(ctx).Do()
+	})
+}
+
+// waitForRegionOperation blocks until the given regional Compute Engine operation (e.g. a
+// subnetwork or router create/delete) has finished, and returns an error if it failed.
+func waitForRegionOperation(ctx context.Context, client *Client, op *compute.Operation) error {
+	return waitForOperation(ctx, op, func() (*compute.Operation, error) {
+		return client.Compute.RegionOperations.Get(client.Project, client.Region, op.Name).Context(ctx).Do()
+	})
+}
+
+func waitForOperation(ctx context.Context, op *compute.Operation, get func() (*compute.Operation, error)) error {
+	if op.Status == "DONE" {
+		return operationError(op)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current, err := get()
+			if err != nil {
+				return err
+			}
+			if current.Status == "DONE" {
+				return operationError(current)
+			}
+		}
+	}
+}
+
+func operationError(op *compute.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("operation %q failed: %s", op.Name, op.Error.Errors[0].Message)
+}
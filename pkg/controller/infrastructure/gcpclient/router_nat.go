@@ -0,0 +1,148 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+func (b *Backend) routerName() string {
+	if b.config.Networks.VPC != nil && b.config.Networks.VPC.CloudRouter != nil && len(b.config.Networks.VPC.CloudRouter.Name) > 0 {
+		return b.config.Networks.VPC.CloudRouter.Name
+	}
+	if b.imported != nil && len(b.imported.CloudRouterName) > 0 {
+		return b.imported.CloudRouterName
+	}
+	return fmt.Sprintf("%s-cloud-router", b.namespace)
+}
+
+func (b *Backend) natName() string {
+	if b.imported != nil && len(b.imported.CloudNATName) > 0 {
+		return b.imported.CloudNATName
+	}
+	return fmt.Sprintf("%s-cloud-nat", b.namespace)
+}
+
+// reconcileCloudRouter creates the Cloud Router for the shoot, unless the user brought their own
+// router alongside a BYO VPC, in which case it is only looked up.
+func (b *Backend) reconcileCloudRouter(ctx context.Context, vpc string) (string, error) {
+	name := b.routerName()
+
+	if b.config.Networks.VPC != nil && b.config.Networks.VPC.CloudRouter != nil && len(b.config.Networks.VPC.CloudRouter.Name) > 0 {
+		if _, err := b.client.Compute.Routers.Get(b.client.Project, b.client.Region, name).Context(ctx).Do(); err != nil {
+			return "", fmt.Errorf("configured Cloud Router %q does not exist: %w", name, err)
+		}
+		return name, nil
+	}
+
+	router := &compute.Router{
+		Name:    name,
+		Network: networkSelfLink(b.client.Project, vpc),
+		Region:  b.client.Region,
+	}
+
+	op, err := b.client.Compute.Routers.Insert(b.client.Project, b.client.Region, router).Context(ctx).Do()
+	if err != nil {
+		if isAlreadyExists(err) {
+			return name, nil
+		}
+		return "", err
+	}
+
+	return name, waitForRegionOperation(ctx, b.client, op)
+}
+
+func (b *Backend) deleteCloudRouter(ctx context.Context) error {
+	if b.config.Networks.VPC != nil && b.config.Networks.VPC.CloudRouter != nil && len(b.config.Networks.VPC.CloudRouter.Name) > 0 {
+		return nil
+	}
+
+	op, err := b.client.Compute.Routers.Delete(b.client.Project, b.client.Region, b.routerName()).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return waitForRegionOperation(ctx, b.client, op)
+}
+
+// reconcileCloudNAT attaches a Cloud NAT gateway to the Cloud Router with the configured
+// MinPortsPerVM, creating it with the NAT IPs auto-allocated.
+func (b *Backend) reconcileCloudNAT(ctx context.Context, router string) (string, error) {
+	name := b.natName()
+
+	minPortsPerVM := int64(2048)
+	if b.config.Networks.CloudNAT != nil && b.config.Networks.CloudNAT.MinPortsPerVM != nil {
+		minPortsPerVM = int64(*b.config.Networks.CloudNAT.MinPortsPerVM)
+	}
+
+	current, err := b.client.Compute.Routers.Get(b.client.Project, b.client.Region, router).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	for _, nat := range current.Nats {
+		if nat.Name == name {
+			return name, nil
+		}
+	}
+
+	current.Nats = append(current.Nats, &compute.RouterNat{
+		Name:                          name,
+		NatIpAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
+		MinPortsPerVm:                 minPortsPerVM,
+	})
+
+	op, err := b.client.Compute.Routers.Patch(b.client.Project, b.client.Region, router, current).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	return name, waitForRegionOperation(ctx, b.client, op)
+}
+
+func (b *Backend) deleteCloudNAT(ctx context.Context) error {
+	router := b.routerName()
+	name := b.natName()
+
+	current, err := b.client.Compute.Routers.Get(b.client.Project, b.client.Region, router).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	nats := current.Nats[:0]
+	for _, nat := range current.Nats {
+		if nat.Name != name {
+			nats = append(nats, nat)
+		}
+	}
+	current.Nats = nats
+
+	op, err := b.client.Compute.Routers.Patch(b.client.Project, b.client.Region, router, current).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return waitForRegionOperation(ctx, b.client, op)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	iam "google.golang.org/api/iam/v1"
+)
+
+func (b *Backend) serviceAccountID() string {
+	return fmt.Sprintf("shoot--%s", b.namespace)
+}
+
+func (b *Backend) serviceAccountName() string {
+	return fmt.Sprintf("projects/%s/serviceAccounts/%s@%s.iam.gserviceaccount.com", b.client.Project, b.serviceAccountID(), b.client.Project)
+}
+
+// reconcileServiceAccount creates the service account used by shoot nodes, returning its email.
+// It authenticates with the same shoot-specific credentials as the Compute calls, since there is
+// no ambient identity that is valid for every shoot's project.
+func (b *Backend) reconcileServiceAccount(ctx context.Context) (string, error) {
+	if b.imported != nil && len(b.imported.ServiceAccountEmail) > 0 {
+		return b.imported.ServiceAccountEmail, nil
+	}
+
+	if existing, err := b.client.IAM.Projects.ServiceAccounts.Get(b.serviceAccountName()).Context(ctx).Do(); err == nil {
+		return existing.Email, nil
+	}
+
+	sa, err := b.client.IAM.Projects.ServiceAccounts.Create(fmt.Sprintf("projects/%s", b.client.Project), &iam.CreateServiceAccountRequest{
+		AccountId: b.serviceAccountID(),
+		ServiceAccount: &iam.ServiceAccount{
+			DisplayName: fmt.Sprintf("Shoot %s", b.namespace),
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	return sa.Email, nil
+}
+
+// deleteServiceAccount deletes the shoot's node service account.
+func (b *Backend) deleteServiceAccount(ctx context.Context) error {
+	_, err := b.client.IAM.Projects.ServiceAccounts.Delete(b.serviceAccountName()).Context(ctx).Do()
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
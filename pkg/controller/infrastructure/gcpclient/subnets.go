@@ -0,0 +1,129 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func (b *Backend) subnetNodesName() string {
+	if b.imported != nil && len(b.imported.SubnetNodes) > 0 {
+		return b.imported.SubnetNodes
+	}
+	return fmt.Sprintf("%s-nodes", b.namespace)
+}
+
+func (b *Backend) subnetInternalName() string {
+	if b.imported != nil && b.imported.SubnetInternal != nil {
+		return *b.imported.SubnetInternal
+	}
+	return fmt.Sprintf("%s-internal", b.namespace)
+}
+
+// workersCIDR returns the worker (nodes) subnet CIDR, falling back to the deprecated
+// Networks.Worker field for shoots that have not migrated to Networks.Workers yet - mirroring
+// the same fallback ComputeTerraformerChartValues applies in pkg/internal/infrastructure.
+func (b *Backend) workersCIDR() string {
+	if b.config.Networks.Workers != "" {
+		return b.config.Networks.Workers
+	}
+	return b.config.Networks.Worker
+}
+
+// reconcileSubnets creates the nodes subnet, and the internal subnet if configured, and returns
+// the resulting apiv1alpha1.Subnet list.
+func (b *Backend) reconcileSubnets(ctx context.Context, vpc string) ([]apiv1alpha1.Subnet, error) {
+	nodes, err := b.reconcileSubnet(ctx, b.subnetNodesName(), b.workersCIDR(), vpc)
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := []apiv1alpha1.Subnet{
+		{
+			Purpose: apiv1alpha1.PurposeNodes,
+			Name:    nodes,
+		},
+	}
+
+	if b.config.Networks.Internal != nil {
+		internal, err := b.reconcileSubnet(ctx, b.subnetInternalName(), *b.config.Networks.Internal, vpc)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, apiv1alpha1.Subnet{
+			Purpose: apiv1alpha1.PurposeInternal,
+			Name:    internal,
+		})
+	}
+
+	return subnets, nil
+}
+
+func (b *Backend) reconcileSubnet(ctx context.Context, name, cidr, vpc string) (string, error) {
+	subnet := &compute.Subnetwork{
+		Name:        name,
+		IpCidrRange: cidr,
+		Network:     networkSelfLink(b.client.Project, vpc),
+		Region:      b.client.Region,
+		Labels:      ownerLabels(b.namespace),
+	}
+
+	op, err := b.client.Compute.Subnetworks.Insert(b.client.Project, b.client.Region, subnet).Context(ctx).Do()
+	if err != nil {
+		if isAlreadyExists(err) {
+			return name, nil
+		}
+		return "", err
+	}
+
+	return name, waitForRegionOperation(ctx, b.client, op)
+}
+
+// deleteSubnets deletes every subnetwork in the region carrying this shoot's OwnerLabelKey label,
+// rather than guessing the nodes/internal subnet names, so a subnet renamed out-of-band (or one
+// adopted under a different name via ImportTerraformState) is still found and removed.
+func (b *Backend) deleteSubnets(ctx context.Context) error {
+	filter := fmt.Sprintf("labels.%s=%s", OwnerLabelKey, b.namespace)
+
+	list, err := b.client.Compute.Subnetworks.List(b.client.Project, b.client.Region).Filter(filter).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, subnet := range list.Items {
+		op, err := b.client.Compute.Subnetworks.Delete(b.client.Project, b.client.Region, subnet.Name).Context(ctx).Do()
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := waitForRegionOperation(ctx, b.client, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func networkSelfLink(project, name string) string {
+	return fmt.Sprintf("projects/%s/global/networks/%s", project, name)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpclient
+
+import (
+	"context"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func (b *Backend) vpcName() string {
+	if b.config.Networks.VPC != nil {
+		return b.config.Networks.VPC.Name
+	}
+	if b.imported != nil && len(b.imported.VPCName) > 0 {
+		return b.imported.VPCName
+	}
+	return b.namespace
+}
+
+// reconcileVPC creates the shoot VPC if it does not already exist and returns its name. If the
+// user configured a BYO VPC, it is merely looked up and never modified.
+func (b *Backend) reconcileVPC(ctx context.Context) (string, error) {
+	name := b.vpcName()
+
+	if b.config.Networks.VPC != nil {
+		if _, err := b.client.Compute.Networks.Get(b.client.Project, name).Context(ctx).Do(); err != nil {
+			return "", fmt.Errorf("configured VPC %q does not exist: %w", name, err)
+		}
+		return name, nil
+	}
+
+	network := &compute.Network{
+		Name:                  name,
+		AutoCreateSubnetworks: false,
+	}
+
+	op, err := b.client.Compute.Networks.Insert(b.client.Project, network).Context(ctx).Do()
+	if err != nil {
+		if isAlreadyExists(err) {
+			return name, nil
+		}
+		return "", err
+	}
+
+	return name, waitForGlobalOperation(ctx, b.client, op)
+}
+
+// deleteVPC deletes the shoot VPC, unless it was configured as a BYO VPC.
+func (b *Backend) deleteVPC(ctx context.Context) error {
+	if b.config.Networks.VPC != nil {
+		return nil
+	}
+
+	op, err := b.client.Compute.Networks.Delete(b.client.Project, b.vpcName()).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return waitForGlobalOperation(ctx, b.client, op)
+}
+
+func isNotFound(err error) bool {
+	gErr, ok := err.(*googleapi.Error)
+	return ok && gErr.Code == 404
+}
+
+func isAlreadyExists(err error) bool {
+	gErr, ok := err.(*googleapi.Error)
+	return ok && gErr.Code == 409
+}
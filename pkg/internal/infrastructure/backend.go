@@ -0,0 +1,70 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// UseFlowAnnotation is the annotation on the Infrastructure resource that, when set to "true",
+// opts the shoot into the native (SDK based) reconciliation backend instead of Terraformer.
+const UseFlowAnnotation = "gcp.provider.extensions.gardener.cloud/use-flow"
+
+// Backend identifies which implementation reconciles an Infrastructure resource.
+type Backend string
+
+const (
+	// BackendTerraformer reconciles the infrastructure by rendering and applying the gcp-infra
+	// Terraform chart through Terraformer.
+	BackendTerraformer Backend = "terraformer"
+	// BackendFlow reconciles the infrastructure natively through the Google Cloud SDK, without
+	// shelling out to a Terraformer pod.
+	BackendFlow Backend = "flow"
+)
+
+// InfrastructureBackend reconciles and deletes the cloud resources backing an Infrastructure
+// resource and reports the resulting status. TerraformerBackend and the native backend in
+// pkg/controller/infrastructure/gcpclient are the two implementations.
+type InfrastructureBackend interface {
+	// Reconcile creates or updates the infrastructure resources and returns the resulting status.
+	Reconcile(ctx context.Context) (*apiv1alpha1.InfrastructureStatus, error)
+	// Delete tears down the infrastructure resources owned by this backend.
+	Delete(ctx context.Context) error
+}
+
+// SelectBackend determines which InfrastructureBackend should reconcile the given Infrastructure
+// resource. The per-resource UseFlowAnnotation, when set to "true" or "false", takes precedence
+// over the controller-wide useFlowByDefault setting, so individual shoots can opt in or out of
+// the native backend; any other annotation value is ignored.
+func SelectBackend(infra *extensionsv1alpha1.Infrastructure, useFlowByDefault bool) Backend {
+	if v, ok := infra.Annotations[UseFlowAnnotation]; ok {
+		if v == "true" {
+			return BackendFlow
+		}
+		if v == "false" {
+			return BackendTerraformer
+		}
+	}
+
+	if useFlowByDefault {
+		return BackendFlow
+	}
+
+	return BackendTerraformer
+}
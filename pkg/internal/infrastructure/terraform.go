@@ -16,6 +16,7 @@ package infrastructure
 
 import (
 	"path/filepath"
+	"strings"
 
 	api "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
 	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
@@ -47,6 +48,24 @@ const (
 	TerraformOutputKeyCloudNAT = "cloud_nat"
 	// TerraformOutputKeyCloudRouter is the name of the cloud_router terraform output variable.
 	TerraformOutputKeyCloudRouter = "cloud_router"
+	// TerraformerOutputKeyInternalLBAddress is the name of the internal_lb_address terraform output variable.
+	TerraformerOutputKeyInternalLBAddress = "internal_lb_address"
+	// TerraformerOutputKeyBastionSubnet is the name of the subnet_bastion terraform output variable.
+	TerraformerOutputKeyBastionSubnet = "subnet_bastion"
+	// TerraformerOutputKeyBastionInstanceName is the name of the bastion_instance_name terraform output variable.
+	TerraformerOutputKeyBastionInstanceName = "bastion_instance_name"
+	// TerraformerOutputKeyPSAConnectionName is the name of the psa_connection_name terraform output variable.
+	TerraformerOutputKeyPSAConnectionName = "psa_connection_name"
+	// TerraformerOutputKeyPSARanges is the name of the psa_ranges terraform output variable.
+	TerraformerOutputKeyPSARanges = "psa_ranges"
+	// TerraformerOutputKeySubnetNodesIPv6CIDRRange is the name of the subnet_nodes_ipv6_cidr_range terraform output variable.
+	TerraformerOutputKeySubnetNodesIPv6CIDRRange = "subnet_nodes_ipv6_cidr_range"
+	// TerraformerOutputKeySubnetInternalIPv6CIDRRange is the name of the subnet_internal_ipv6_cidr_range terraform output variable.
+	TerraformerOutputKeySubnetInternalIPv6CIDRRange = "subnet_internal_ipv6_cidr_range"
+	// TerraformerOutputKeyCloudNATIPs is the name of the cloud_nat_ips terraform output variable.
+	TerraformerOutputKeyCloudNATIPs = "cloud_nat_ips"
+	// TerraformerOutputKeyVPCSelfLink is the name of the vpc_self_link terraform output variable.
+	TerraformerOutputKeyVPCSelfLink = "vpc_self_link"
 )
 
 var (
@@ -70,8 +89,11 @@ func ComputeTerraformerChartValues(
 		createCloudRouter = true
 		cloudRouterName   string
 		minPortsPerVM     = int32(2048)
+		createInternalLB  = false
+		bastionSubnetCIDR string
 	)
 
+	var hostProject string
 	if config.Networks.VPC != nil {
 		vpcName = config.Networks.VPC.Name
 		createVPC = false
@@ -80,11 +102,92 @@ func ComputeTerraformerChartValues(
 		if config.Networks.VPC.CloudRouter != nil && len(config.Networks.VPC.CloudRouter.Name) > 0 {
 			cloudRouterName = config.Networks.VPC.CloudRouter.Name
 		}
+
+		if config.Networks.VPC.HostProject != nil {
+			hostProject = *config.Networks.VPC.HostProject
+		}
+	}
+
+	cloudNAT := map[string]interface{}{
+		"minPortsPerVM": minPortsPerVM,
 	}
 
 	if config.Networks.CloudNAT != nil {
 		if config.Networks.CloudNAT.MinPortsPerVM != nil {
-			minPortsPerVM = *config.Networks.CloudNAT.MinPortsPerVM
+			cloudNAT["minPortsPerVM"] = *config.Networks.CloudNAT.MinPortsPerVM
+		}
+
+		if len(config.Networks.CloudNAT.NatIPNames) > 0 {
+			cloudNAT["natIPNames"] = config.Networks.CloudNAT.NatIPNames
+			cloudNAT["natIPAllocateOption"] = "MANUAL_ONLY"
+		} else {
+			cloudNAT["natIPAllocateOption"] = "AUTO_ONLY"
+		}
+
+		if config.Networks.CloudNAT.EnableEndpointIndependentMapping != nil {
+			cloudNAT["enableEndpointIndependentMapping"] = *config.Networks.CloudNAT.EnableEndpointIndependentMapping
+		}
+
+		if config.Networks.CloudNAT.EnableDynamicPortAllocation != nil {
+			cloudNAT["enableDynamicPortAllocation"] = *config.Networks.CloudNAT.EnableDynamicPortAllocation
+		}
+
+		if config.Networks.CloudNAT.MaxPortsPerVM != nil {
+			cloudNAT["maxPortsPerVM"] = *config.Networks.CloudNAT.MaxPortsPerVM
+		}
+
+		if config.Networks.CloudNAT.UdpIdleTimeoutSec != nil {
+			cloudNAT["udpIdleTimeoutSec"] = *config.Networks.CloudNAT.UdpIdleTimeoutSec
+		}
+
+		if config.Networks.CloudNAT.TcpEstablishedIdleTimeoutSec != nil {
+			cloudNAT["tcpEstablishedIdleTimeoutSec"] = *config.Networks.CloudNAT.TcpEstablishedIdleTimeoutSec
+		}
+
+		if config.Networks.CloudNAT.TcpTransitoryIdleTimeoutSec != nil {
+			cloudNAT["tcpTransitoryIdleTimeoutSec"] = *config.Networks.CloudNAT.TcpTransitoryIdleTimeoutSec
+		}
+
+		if config.Networks.CloudNAT.IcmpIdleTimeoutSec != nil {
+			cloudNAT["icmpIdleTimeoutSec"] = *config.Networks.CloudNAT.IcmpIdleTimeoutSec
+		}
+	}
+
+	if config.Networks.APIServerAccess != nil {
+		createInternalLB = true
+
+		if config.Networks.APIServerAccess.BastionSubnet != nil {
+			bastionSubnetCIDR = *config.Networks.APIServerAccess.BastionSubnet
+		}
+	}
+
+	stackType := "IPV4_ONLY"
+	for _, family := range config.Networks.IPFamilies {
+		if family == api.IPFamilyIPv6 {
+			stackType = "IPV4_IPV6"
+			break
+		}
+	}
+
+	var privateServiceAccess map[string]interface{}
+	if psa := config.Networks.PrivateServiceAccess; psa != nil {
+		exportCustomRoutes := false
+		if psa.ExportCustomRoutes != nil {
+			exportCustomRoutes = *psa.ExportCustomRoutes
+		}
+
+		importCustomRoutes := false
+		if psa.ImportCustomRoutes != nil {
+			importCustomRoutes = *psa.ImportCustomRoutes
+		}
+
+		privateServiceAccess = map[string]interface{}{
+			"name":               psa.Name,
+			"cidr":               psa.CIDR,
+			"purpose":            "VPC_PEERING",
+			"producers":          psa.Producers,
+			"exportCustomRoutes": exportCustomRoutes,
+			"importCustomRoutes": importCustomRoutes,
 		}
 	}
 
@@ -108,21 +211,33 @@ func ComputeTerraformerChartValues(
 		"google": map[string]interface{}{
 			"region":  infra.Spec.Region,
 			"project": account.ProjectID,
+			"hostProject": map[string]interface{}{
+				"enabled": len(hostProject) > 0,
+				"project": hostProject,
+			},
 		},
 		"create": map[string]interface{}{
 			"vpc":         createVPC,
 			"cloudRouter": createCloudRouter,
+			"internalLB":  createInternalLB,
 		},
 		"vpc":         vpc,
 		"clusterName": infra.Namespace,
 		"networks": map[string]interface{}{
-			"pods":     extensionscontroller.GetPodNetwork(cluster),
-			"services": extensionscontroller.GetServiceNetwork(cluster),
-			"workers":  workersCIDR,
-			"internal": config.Networks.Internal,
-			"cloudNAT": map[string]interface{}{
-				"minPortsPerVM": minPortsPerVM,
+			"pods":          extensionscontroller.GetPodNetwork(cluster),
+			"services":      extensionscontroller.GetServiceNetwork(cluster),
+			"workers":       workersCIDR,
+			"internal":      config.Networks.Internal,
+			"subnetBastion": bastionSubnetCIDR,
+			"subnetNodes": map[string]interface{}{
+				"stackType":      stackType,
+				"ipv6AccessType": config.Networks.NodesIPv6AccessType,
+			},
+			"subnetInternal": map[string]interface{}{
+				"stackType":      stackType,
+				"ipv6AccessType": config.Networks.InternalIPv6AccessType,
 			},
+			"cloudNAT": cloudNAT,
 		},
 		"outputKeys": map[string]interface{}{
 			"vpcName":             TerraformerOutputKeyVPCName,
@@ -131,9 +246,20 @@ func ComputeTerraformerChartValues(
 			"serviceAccountEmail": TerraformerOutputKeyServiceAccountEmail,
 			"subnetNodes":         TerraformerOutputKeySubnetNodes,
 			"subnetInternal":      TerraformerOutputKeySubnetInternal,
+			"internalLBAddress":   TerraformerOutputKeyInternalLBAddress,
+			"psaConnectionName":   TerraformerOutputKeyPSAConnectionName,
+			"psaRanges":           TerraformerOutputKeyPSARanges,
+			"subnetNodesIPv6":     TerraformerOutputKeySubnetNodesIPv6CIDRRange,
+			"subnetInternalIPv6":  TerraformerOutputKeySubnetInternalIPv6CIDRRange,
+			"cloudNATIPs":         TerraformerOutputKeyCloudNATIPs,
+			"vpcSelfLink":         TerraformerOutputKeyVPCSelfLink,
 		},
 	}
 
+	if privateServiceAccess != nil {
+		values["networks"].(map[string]interface{})["privateServiceAccess"] = privateServiceAccess
+	}
+
 	if config.Networks.FlowLogs != nil {
 		fl := make(map[string]interface{})
 
@@ -199,6 +325,31 @@ type TerraformState struct {
 	SubnetNodes string
 	// SubnetInternal is the CIDR of the internal subnet of an infrastructure.
 	SubnetInternal *string
+	// InternalLBAddress is the IP address of the internal TCP/UDP load balancer fronting the
+	// API server, if APIServerAccess is configured.
+	InternalLBAddress *string
+	// BastionSubnet is the name of the bastion/jump host subnet, if APIServerAccess is configured
+	// with a bastion subnet.
+	BastionSubnet *string
+	// BastionInstanceName is the name of the bastion/jump host instance, if APIServerAccess is
+	// configured.
+	BastionInstanceName *string
+	// PSAConnectionName is the name of the google_service_networking_connection used for Private
+	// Service Access, if PrivateServiceAccess is configured.
+	PSAConnectionName *string
+	// PSARanges are the reserved global internal IP ranges allocated for Private Service Access.
+	PSARanges []string
+	// SubnetNodesIPv6CIDR is the allocated IPv6 CIDR range of the nodes subnet, if dual-stack is enabled.
+	SubnetNodesIPv6CIDR *string
+	// SubnetInternalIPv6CIDR is the allocated IPv6 CIDR range of the internal subnet, if dual-stack is enabled.
+	SubnetInternalIPv6CIDR *string
+	// NatIPs are the static external IP addresses reserved for Cloud NAT, if NatIPNames is configured.
+	NatIPs []string
+	// VPCSelfLink is the host-project-qualified self link of the VPC, if the VPC lives in a
+	// Shared VPC host project.
+	VPCSelfLink *string
+	// HostProject is the project ID of the Shared VPC host project, if configured.
+	HostProject *string
 }
 
 // ExtractTerraformState extracts the TerraformState from the given Terraformer.
@@ -217,11 +368,49 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		outputKeys = append(outputKeys, TerraformOutputKeyCloudRouter, TerraformOutputKeyCloudNAT)
 	}
 
+	hasNatIPNames := config.Networks.CloudNAT != nil && len(config.Networks.CloudNAT.NatIPNames) > 0
+	if hasNatIPNames {
+		outputKeys = append(outputKeys, TerraformerOutputKeyCloudNATIPs)
+	}
+
+	hasHostProject := config.Networks.VPC != nil && config.Networks.VPC.HostProject != nil
+	if hasHostProject {
+		outputKeys = append(outputKeys, TerraformerOutputKeyVPCSelfLink)
+	}
+
 	hasInternal := config.Networks.Internal != nil
 	if hasInternal {
 		outputKeys = append(outputKeys, TerraformerOutputKeySubnetInternal)
 	}
 
+	hasAPIServerAccess := config.Networks.APIServerAccess != nil
+	if hasAPIServerAccess {
+		outputKeys = append(outputKeys, TerraformerOutputKeyInternalLBAddress)
+
+		if config.Networks.APIServerAccess.BastionSubnet != nil {
+			outputKeys = append(outputKeys, TerraformerOutputKeyBastionSubnet, TerraformerOutputKeyBastionInstanceName)
+		}
+	}
+
+	hasPrivateServiceAccess := config.Networks.PrivateServiceAccess != nil
+	if hasPrivateServiceAccess {
+		outputKeys = append(outputKeys, TerraformerOutputKeyPSAConnectionName, TerraformerOutputKeyPSARanges)
+	}
+
+	isDualStack := false
+	for _, family := range config.Networks.IPFamilies {
+		if family == api.IPFamilyIPv6 {
+			isDualStack = true
+			break
+		}
+	}
+	if isDualStack {
+		outputKeys = append(outputKeys, TerraformerOutputKeySubnetNodesIPv6CIDRRange)
+		if hasInternal {
+			outputKeys = append(outputKeys, TerraformerOutputKeySubnetInternalIPv6CIDRRange)
+		}
+	}
+
 	vars, err := tf.GetStateOutputVariables(outputKeys...)
 	if err != nil {
 		return nil, err
@@ -238,10 +427,57 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		state.CloudNATName = vars[TerraformOutputKeyCloudNAT]
 	}
 
+	if hasNatIPNames {
+		if ips := vars[TerraformerOutputKeyCloudNATIPs]; len(ips) > 0 {
+			state.NatIPs = strings.Split(ips, ",")
+		}
+	}
+
+	if hasHostProject {
+		vpcSelfLink := vars[TerraformerOutputKeyVPCSelfLink]
+		state.VPCSelfLink = &vpcSelfLink
+
+		hostProject := *config.Networks.VPC.HostProject
+		state.HostProject = &hostProject
+	}
+
 	if hasInternal {
 		subnetInternal := vars[TerraformerOutputKeySubnetInternal]
 		state.SubnetInternal = &subnetInternal
 	}
+
+	if hasAPIServerAccess {
+		internalLBAddress := vars[TerraformerOutputKeyInternalLBAddress]
+		state.InternalLBAddress = &internalLBAddress
+
+		if config.Networks.APIServerAccess.BastionSubnet != nil {
+			bastionSubnet := vars[TerraformerOutputKeyBastionSubnet]
+			state.BastionSubnet = &bastionSubnet
+
+			bastionInstanceName := vars[TerraformerOutputKeyBastionInstanceName]
+			state.BastionInstanceName = &bastionInstanceName
+		}
+	}
+
+	if isDualStack {
+		subnetNodesIPv6 := vars[TerraformerOutputKeySubnetNodesIPv6CIDRRange]
+		state.SubnetNodesIPv6CIDR = &subnetNodesIPv6
+
+		if hasInternal {
+			subnetInternalIPv6 := vars[TerraformerOutputKeySubnetInternalIPv6CIDRRange]
+			state.SubnetInternalIPv6CIDR = &subnetInternalIPv6
+		}
+	}
+
+	if hasPrivateServiceAccess {
+		psaConnectionName := vars[TerraformerOutputKeyPSAConnectionName]
+		state.PSAConnectionName = &psaConnectionName
+
+		if ranges := vars[TerraformerOutputKeyPSARanges]; len(ranges) > 0 {
+			state.PSARanges = strings.Split(ranges, ",")
+		}
+	}
+
 	return state, nil
 }
 
@@ -257,8 +493,9 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 				},
 				Subnets: []apiv1alpha1.Subnet{
 					{
-						Purpose: apiv1alpha1.PurposeNodes,
-						Name:    state.SubnetNodes,
+						Purpose:  apiv1alpha1.PurposeNodes,
+						Name:     state.SubnetNodes,
+						IPv6CIDR: state.SubnetNodesIPv6CIDR,
 					},
 				},
 			},
@@ -272,10 +509,40 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 		}
 	}
 
+	if state.HostProject != nil {
+		status.Networks.VPC.HostProject = state.HostProject
+	}
+
+	if state.InternalLBAddress != nil {
+		status.Networks.APIServerAccess = &apiv1alpha1.APIServerAccessStatus{
+			InternalLBAddress: *state.InternalLBAddress,
+		}
+
+		if state.BastionSubnet != nil {
+			status.Networks.APIServerAccess.BastionSubnet = *state.BastionSubnet
+		}
+
+		if state.BastionInstanceName != nil {
+			status.Networks.APIServerAccess.BastionInstanceName = *state.BastionInstanceName
+		}
+	}
+
+	if state.PSAConnectionName != nil {
+		status.Networks.PrivateServiceAccess = &apiv1alpha1.PrivateServiceAccessStatus{
+			ConnectionName: *state.PSAConnectionName,
+			Ranges:         state.PSARanges,
+		}
+	}
+
+	if len(state.NatIPs) > 0 {
+		status.Networks.NatIPs = state.NatIPs
+	}
+
 	if state.SubnetInternal != nil {
 		status.Networks.Subnets = append(status.Networks.Subnets, apiv1alpha1.Subnet{
-			Purpose: apiv1alpha1.PurposeInternal,
-			Name:    *state.SubnetInternal,
+			Purpose:  apiv1alpha1.PurposeInternal,
+			Name:     *state.SubnetInternal,
+			IPv6CIDR: state.SubnetInternalIPv6CIDR,
 		})
 	}
 
@@ -290,4 +557,4 @@ func ComputeStatus(tf terraformer.Terraformer, config *api.InfrastructureConfig)
 	}
 
 	return StatusFromTerraformState(state), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-gcp/pkg/internal"
+	. "github.com/gardener/gardener-extension-provider-gcp/pkg/internal/infrastructure"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeTerraformerChartValues_DefaultVPC(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	create, ok := values["create"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected create section to be a map")
+	}
+	if create["vpc"] != true {
+		t.Errorf("expected a new VPC to be created when none is configured")
+	}
+
+	networks, ok := values["networks"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected networks section to be a map")
+	}
+	if networks["workers"] != "10.250.0.0/16" {
+		t.Errorf("expected workers CIDR to be passed through, got %v", networks["workers"])
+	}
+}
+
+func TestComputeTerraformerChartValues_WorkerFallback(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Worker: "10.250.0.0/16",
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	networks := values["networks"].(map[string]interface{})
+	if networks["workers"] != "10.250.0.0/16" {
+		t.Errorf("expected deprecated Worker field to be used as fallback, got %v", networks["workers"])
+	}
+}
+
+func TestComputeTerraformerChartValues_ExistingVPC(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+			VPC:     &api.VPC{Name: "existing-vpc"},
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	create := values["create"].(map[string]interface{})
+	if create["vpc"] != false {
+		t.Errorf("expected no VPC to be created when one is configured")
+	}
+
+	vpc := values["vpc"].(map[string]interface{})
+	if vpc["name"] != "existing-vpc" {
+		t.Errorf("expected existing VPC name to be used, got %v", vpc["name"])
+	}
+}
+
+func TestComputeTerraformerChartValues_APIServerAccess(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	bastionSubnet := "10.252.0.0/24"
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers:         "10.250.0.0/16",
+			APIServerAccess: &api.APIServerAccessConfig{BastionSubnet: &bastionSubnet},
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	create := values["create"].(map[string]interface{})
+	if create["internalLB"] != true {
+		t.Errorf("expected internalLB to be created when APIServerAccess is configured")
+	}
+
+	networks := values["networks"].(map[string]interface{})
+	if networks["subnetBastion"] != bastionSubnet {
+		t.Errorf("expected subnetBastion to be %q, got %v", bastionSubnet, networks["subnetBastion"])
+	}
+}
+
+func TestComputeTerraformerChartValues_PrivateServiceAccess(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	exportRoutes := true
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+			PrivateServiceAccess: &api.PrivateServiceAccessConfig{
+				Name:               "psa-range",
+				CIDR:               "10.253.0.0/16",
+				Producers:          []string{"servicenetworking.googleapis.com"},
+				ExportCustomRoutes: &exportRoutes,
+			},
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	networks := values["networks"].(map[string]interface{})
+	psa, ok := networks["privateServiceAccess"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected privateServiceAccess section to be set")
+	}
+	if psa["name"] != "psa-range" || psa["cidr"] != "10.253.0.0/16" {
+		t.Errorf("unexpected privateServiceAccess values: %+v", psa)
+	}
+	if psa["exportCustomRoutes"] != true {
+		t.Errorf("expected exportCustomRoutes to be true")
+	}
+	if psa["importCustomRoutes"] != false {
+		t.Errorf("expected importCustomRoutes to default to false")
+	}
+}
+
+func TestComputeTerraformerChartValues_DualStack(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	ipv6AccessType := "EXTERNAL"
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers:             "10.250.0.0/16",
+			IPFamilies:          []api.IPFamily{api.IPFamilyIPv4, api.IPFamilyIPv6},
+			NodesIPv6AccessType: &ipv6AccessType,
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	networks := values["networks"].(map[string]interface{})
+	subnetNodes := networks["subnetNodes"].(map[string]interface{})
+	if subnetNodes["stackType"] != "IPV4_IPV6" {
+		t.Errorf("expected dual-stack stackType, got %v", subnetNodes["stackType"])
+	}
+	got, ok := subnetNodes["ipv6AccessType"].(*string)
+	if !ok || got == nil || *got != ipv6AccessType {
+		t.Errorf("unexpected ipv6AccessType: %v", subnetNodes["ipv6AccessType"])
+	}
+}
+
+func TestExtractTerraformState_DualStack(t *testing.T) {
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers:    "10.250.0.0/16",
+			IPFamilies: []api.IPFamily{api.IPFamilyIPv6},
+		},
+	}
+
+	tf := &fakeTerraformer{outputs: map[string]string{
+		TerraformerOutputKeyVPCName:                  "my-vpc",
+		TerraformerOutputKeySubnetNodes:              "10.250.0.0/16",
+		TerraformerOutputKeyServiceAccountEmail:      "sa@my-project.iam.gserviceaccount.com",
+		TerraformerOutputKeySubnetNodesIPv6CIDRRange: "2600:1900::/64",
+	}}
+
+	state, err := ExtractTerraformState(tf, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.SubnetNodesIPv6CIDR == nil || *state.SubnetNodesIPv6CIDR != "2600:1900::/64" {
+		t.Errorf("unexpected SubnetNodesIPv6CIDR: %v", state.SubnetNodesIPv6CIDR)
+	}
+
+	status := StatusFromTerraformState(state)
+	if status.Networks.Subnets[0].IPv6CIDR == nil || *status.Networks.Subnets[0].IPv6CIDR != "2600:1900::/64" {
+		t.Errorf("unexpected status subnet IPv6CIDR: %v", status.Networks.Subnets[0].IPv6CIDR)
+	}
+}
+
+func TestComputeTerraformerChartValues_CloudNATTuning(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	maxPorts := int32(4096)
+	endpointIndependent := true
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+			CloudNAT: &api.CloudNAT{
+				NatIPNames:                       []string{"my-nat-ip-1", "my-nat-ip-2"},
+				MaxPortsPerVM:                    &maxPorts,
+				EnableEndpointIndependentMapping: &endpointIndependent,
+			},
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	networks := values["networks"].(map[string]interface{})
+	cloudNAT := networks["cloudNAT"].(map[string]interface{})
+
+	if cloudNAT["natIPAllocateOption"] != "MANUAL_ONLY" {
+		t.Errorf("expected MANUAL_ONLY allocate option when natIPNames are set, got %v", cloudNAT["natIPAllocateOption"])
+	}
+	if names, ok := cloudNAT["natIPNames"].([]string); !ok || len(names) != 2 {
+		t.Errorf("expected natIPNames to be passed through, got %v", cloudNAT["natIPNames"])
+	}
+	if cloudNAT["maxPortsPerVM"] != maxPorts {
+		t.Errorf("expected maxPortsPerVM to be %d, got %v", maxPorts, cloudNAT["maxPortsPerVM"])
+	}
+	if cloudNAT["enableEndpointIndependentMapping"] != true {
+		t.Errorf("expected enableEndpointIndependentMapping to be true")
+	}
+}
+
+func TestExtractTerraformState_CloudNATIPs(t *testing.T) {
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers:  "10.250.0.0/16",
+			CloudNAT: &api.CloudNAT{NatIPNames: []string{"my-nat-ip-1", "my-nat-ip-2"}},
+		},
+	}
+
+	tf := &fakeTerraformer{outputs: map[string]string{
+		TerraformerOutputKeyVPCName:             "my-vpc",
+		TerraformerOutputKeySubnetNodes:         "10.250.0.0/16",
+		TerraformerOutputKeyServiceAccountEmail: "sa@my-project.iam.gserviceaccount.com",
+		TerraformOutputKeyCloudRouter:           "my-router",
+		TerraformOutputKeyCloudNAT:              "my-nat",
+		TerraformerOutputKeyCloudNATIPs:         "1.2.3.4,5.6.7.8",
+	}}
+
+	state, err := ExtractTerraformState(tf, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"1.2.3.4", "5.6.7.8"}
+	if !reflect.DeepEqual(state.NatIPs, expected) {
+		t.Errorf("unexpected NatIPs: got %v, want %v", state.NatIPs, expected)
+	}
+
+	status := StatusFromTerraformState(state)
+	if !reflect.DeepEqual(status.Networks.NatIPs, expected) {
+		t.Errorf("unexpected status NatIPs: got %v, want %v", status.Networks.NatIPs, expected)
+	}
+}
+
+func TestComputeTerraformerChartValues_HostProject(t *testing.T) {
+	infra := &extensionsv1alpha1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar"},
+		Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "europe-west1"},
+	}
+	account := &internal.ServiceAccount{ProjectID: "my-project"}
+	hostProject := "host-project"
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+			VPC:     &api.VPC{Name: "shared-vpc", HostProject: &hostProject},
+		},
+	}
+
+	values := ComputeTerraformerChartValues(infra, account, config, &extensionscontroller.Cluster{})
+
+	google := values["google"].(map[string]interface{})
+	googleHostProject, ok := google["hostProject"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected google.hostProject section to be a map")
+	}
+	if googleHostProject["enabled"] != true || googleHostProject["project"] != hostProject {
+		t.Errorf("unexpected hostProject section: %+v", googleHostProject)
+	}
+}
+
+func TestExtractTerraformState_HostProject(t *testing.T) {
+	hostProject := "host-project"
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers: "10.250.0.0/16",
+			VPC:     &api.VPC{Name: "shared-vpc", HostProject: &hostProject},
+		},
+	}
+
+	tf := &fakeTerraformer{outputs: map[string]string{
+		TerraformerOutputKeyVPCName:             "shared-vpc",
+		TerraformerOutputKeySubnetNodes:         "10.250.0.0/16",
+		TerraformerOutputKeyServiceAccountEmail: "sa@my-project.iam.gserviceaccount.com",
+		TerraformerOutputKeyVPCSelfLink:         "projects/host-project/global/networks/shared-vpc",
+	}}
+
+	state, err := ExtractTerraformState(tf, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if state.HostProject == nil || *state.HostProject != hostProject {
+		t.Errorf("unexpected HostProject: %v", state.HostProject)
+	}
+	if state.VPCSelfLink == nil || *state.VPCSelfLink != "projects/host-project/global/networks/shared-vpc" {
+		t.Errorf("unexpected VPCSelfLink: %v", state.VPCSelfLink)
+	}
+
+	status := StatusFromTerraformState(state)
+	if status.Networks.VPC.HostProject == nil || *status.Networks.VPC.HostProject != hostProject {
+		t.Errorf("unexpected status HostProject: %v", status.Networks.VPC.HostProject)
+	}
+}
+
+type fakeTerraformer struct {
+	outputs map[string]string
+}
+
+func (f *fakeTerraformer) GetStateOutputVariables(keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		result[key] = f.outputs[key]
+	}
+	return result, nil
+}
+
+func TestExtractTerraformState_APIServerAccess(t *testing.T) {
+	bastionSubnet := "10.252.0.0/24"
+	config := &api.InfrastructureConfig{
+		Networks: api.NetworkConfig{
+			Workers:         "10.250.0.0/16",
+			APIServerAccess: &api.APIServerAccessConfig{BastionSubnet: &bastionSubnet},
+		},
+	}
+
+	tf := &fakeTerraformer{outputs: map[string]string{
+		TerraformerOutputKeyVPCName:             "my-vpc",
+		TerraformerOutputKeySubnetNodes:         "10.250.0.0/16",
+		TerraformerOutputKeyServiceAccountEmail: "sa@my-project.iam.gserviceaccount.com",
+		TerraformerOutputKeyInternalLBAddress:   "10.250.1.5",
+		TerraformerOutputKeyBastionSubnet:       "10.252.0.0/24",
+		TerraformerOutputKeyBastionInstanceName: "shoot--foo--bar-bastion",
+	}}
+
+	state, err := ExtractTerraformState(tf, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if state.InternalLBAddress == nil || *state.InternalLBAddress != "10.250.1.5" {
+		t.Errorf("unexpected InternalLBAddress: %v", state.InternalLBAddress)
+	}
+	if state.BastionSubnet == nil || *state.BastionSubnet != "10.252.0.0/24" {
+		t.Errorf("unexpected BastionSubnet: %v", state.BastionSubnet)
+	}
+	if state.BastionInstanceName == nil || *state.BastionInstanceName != "shoot--foo--bar-bastion" {
+		t.Errorf("unexpected BastionInstanceName: %v", state.BastionInstanceName)
+	}
+
+	status := StatusFromTerraformState(state)
+	if status.Networks.APIServerAccess == nil {
+		t.Fatalf("expected APIServerAccess status to be set")
+	}
+	if status.Networks.APIServerAccess.InternalLBAddress != "10.250.1.5" {
+		t.Errorf("unexpected status InternalLBAddress: %s", status.Networks.APIServerAccess.InternalLBAddress)
+	}
+	if status.Networks.APIServerAccess.BastionInstanceName != "shoot--foo--bar-bastion" {
+		t.Errorf("unexpected status BastionInstanceName: %s", status.Networks.APIServerAccess.BastionInstanceName)
+	}
+}
+
+func TestStatusFromTerraformState(t *testing.T) {
+	internalSubnet := "10.251.0.0/16"
+
+	state := &TerraformState{
+		VPCName:             "my-vpc",
+		CloudRouterName:     "my-router",
+		ServiceAccountEmail: "sa@my-project.iam.gserviceaccount.com",
+		SubnetNodes:         "10.250.0.0/16",
+		SubnetInternal:      &internalSubnet,
+	}
+
+	status := StatusFromTerraformState(state)
+
+	expectedSubnets := []apiv1alpha1.Subnet{
+		{Purpose: apiv1alpha1.PurposeNodes, Name: "10.250.0.0/16"},
+		{Purpose: apiv1alpha1.PurposeInternal, Name: "10.251.0.0/16"},
+	}
+	if !reflect.DeepEqual(status.Networks.Subnets, expectedSubnets) {
+		t.Errorf("unexpected subnets: got %+v, want %+v", status.Networks.Subnets, expectedSubnets)
+	}
+
+	if status.Networks.VPC.Name != "my-vpc" {
+		t.Errorf("unexpected VPC name: %s", status.Networks.VPC.Name)
+	}
+
+	if status.Networks.VPC.CloudRouter == nil || status.Networks.VPC.CloudRouter.Name != "my-router" {
+		t.Errorf("unexpected cloud router: %+v", status.Networks.VPC.CloudRouter)
+	}
+
+	if status.ServiceAccountEmail != "sa@my-project.iam.gserviceaccount.com" {
+		t.Errorf("unexpected service account email: %s", status.ServiceAccountEmail)
+	}
+}
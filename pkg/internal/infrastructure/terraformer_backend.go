@@ -0,0 +1,52 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+
+	api "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/pkg/terraformer"
+)
+
+// TerraformerBackend is the InfrastructureBackend implementation that reconciles the
+// infrastructure by applying the rendered gcp-infra Terraform chart through Terraformer.
+// The apply of the chart is driven by the caller (the infrastructure actuator); Reconcile only
+// derives the resulting status from the Terraformer state, while Delete destroys the stack.
+type TerraformerBackend struct {
+	tf     terraformer.Terraformer
+	config *api.InfrastructureConfig
+}
+
+// NewTerraformerBackend creates a new TerraformerBackend for the given Terraformer and
+// InfrastructureConfig.
+func NewTerraformerBackend(tf terraformer.Terraformer, config *api.InfrastructureConfig) *TerraformerBackend {
+	return &TerraformerBackend{
+		tf:     tf,
+		config: config,
+	}
+}
+
+// Reconcile computes the InfrastructureStatus from the Terraformer state. The Terraform apply
+// itself is expected to have already run.
+func (b *TerraformerBackend) Reconcile(_ context.Context) (*apiv1alpha1.InfrastructureStatus, error) {
+	return ComputeStatus(b.tf, b.config)
+}
+
+// Delete destroys the Terraform stack managed by this backend.
+func (b *TerraformerBackend) Delete(ctx context.Context) error {
+	return b.tf.Destroy(ctx)
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package internal contains types and constants shared across the GCP provider controllers.
+package internal
+
+// InternalChartsPath is the path to the internal charts.
+const InternalChartsPath = "charts/internal"
+
+// ServiceAccount represents a GCP service account.
+type ServiceAccount struct {
+	// Raw is the raw representation of the service account credentials.
+	Raw []byte
+	// ProjectID is the ID of the GCP project the service account belongs to.
+	ProjectID string
+	// Email is the service account's email address.
+	Email string
+}